@@ -0,0 +1,14 @@
+// Copyright 2011 Cloud Instruments Co. Ltd. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package common
+
+// CallerPrettyfier lets callers shorten the file/function names that end up
+// in a formatted log line, e.g. turning
+// "github.com/foo/bar/baz.(*T).Method" into "baz.Method", or stripping a
+// GOPATH prefix. A formatter given one (NewJSONFormatter takes it as an
+// argument) runs it once per message, rather than it being reimplemented at
+// every call site. LogConfig has a field of this type, but no formatter
+// reads it from there.
+type CallerPrettyfier func(context *LogContext) (file string, function string)
@@ -0,0 +1,58 @@
+// Copyright 2011 Cloud Instruments Co. Ltd. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package common
+
+import "time"
+
+// LogContext carries everything about a single logging call site that a
+// formatter or receiver might need to render it: when it happened, where
+// it happened, and (via WithFields) any structured data attached to it.
+type LogContext struct {
+	callTime time.Time
+	file     string
+	function string
+	line     int
+	fields   map[string]interface{}
+}
+
+// NewLogContext builds a LogContext from known call-site details. It
+// exists so tests (and anything else outside of this package) can build a
+// LogContext with chosen call-site details, which neither SpecificContext
+// (a runtime.Caller lookup) nor EmptyContext (no call-site details) gives.
+func NewLogContext(file, function string, line int, callTime time.Time) *LogContext {
+	return &LogContext{callTime: callTime, file: file, function: function, line: line}
+}
+
+// CallTime returns when the log call was made.
+func (context *LogContext) CallTime() time.Time {
+	return context.callTime
+}
+
+// FileName returns the source file the log call was made from.
+func (context *LogContext) FileName() string {
+	return context.file
+}
+
+// Func returns the function the log call was made from.
+func (context *LogContext) Func() string {
+	return context.function
+}
+
+// Line returns the source line the log call was made from.
+func (context *LogContext) Line() int {
+	return context.line
+}
+
+// Fields returns the structured key/value pairs attached to context via
+// SetFields, or nil if WithFields was never used for this message.
+func (context *LogContext) Fields() map[string]interface{} {
+	return context.fields
+}
+
+// SetFields attaches fields to context so that they are carried through
+// dispatcher.Dispatch to every writer, e.g. a JSONFormatter.
+func (context *LogContext) SetFields(fields map[string]interface{}) {
+	context.fields = fields
+}
@@ -0,0 +1,142 @@
+// Copyright 2011 Cloud Instruments Co. Ltd. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package config holds the result of parsing a <sealog> XML document
+// (LogConfig) and a registry that maps a receiver's XML element name to
+// the Go constructor that builds it.
+//
+// NOTE: there is no <sealog> document parser in this repository yet (no
+// encoding/xml decoding of <outputs>/<filter>/receiver elements), so
+// nothing currently calls RegisterReceiverFactory's entries through
+// NewReceiver from an actual config file. Until that parser exists, the
+// receivers registered here (conn, syslog, file, smtp, and any <custom>
+// factories) are reachable only by constructing them directly in Go
+// (NewConnWriter, NewSyslogWriter, ...), not via XML.
+package config
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	. "github.com/cihub/sealog/common"
+	"github.com/cihub/sealog/dispatchers"
+)
+
+// LogConfig holds everything parsed from a <sealog> document: the
+// dispatcher tree built from <outputs>, plus the handful of top-level
+// attributes that shape logging behavior directly rather than through a
+// receiver.
+type LogConfig struct {
+	RootDispatcher dispatchers.DispatcherInterface
+
+	// MinLevelForCaller is the lowest level for which loggers capture
+	// caller information (via runtime.Caller) at all. Messages below it
+	// get an EmptyContext instead of a SpecificContext, since the lookup
+	// dominates the cost of logging a message on a high-throughput,
+	// low-severity path such as Trace. Nothing in this repository reads a
+	// <sealog minlevelforcaller="..."> attribute into it (see the package
+	// doc); it is set on LogConfig directly in Go. The zero value captures
+	// the caller for every level.
+	MinLevelForCaller LogLevel
+
+	// CallerPrettyfier holds a hook that shortens the file/function a
+	// message carries. Nothing in this repository reads this field: a
+	// formatter applies a prettyfier only when one is passed to it
+	// directly, as NewJSONFormatter's argument.
+	CallerPrettyfier CallerPrettyfier
+}
+
+// ReceiverFactory builds a receiver (an io.Writer, a
+// dispatchers.LevelWriterInterface, or a dispatchers.DispatcherInterface)
+// from the attributes a would-be XML element of the same name would carry,
+// e.g. net/addr for "conn". See the package doc for the current state of
+// XML wiring: this registry has no caller yet.
+type ReceiverFactory func(attrs map[string]string) (interface{}, error)
+
+var (
+	receiverFactoriesMu sync.RWMutex
+	receiverFactories   = make(map[string]ReceiverFactory)
+)
+
+// RegisterReceiverFactory makes a receiver type constructible by name
+// (e.g. "conn"). Each built-in receiver type registers itself from an
+// init() func in the package that implements it, so this package never
+// needs to import them directly. This only makes the receiver reachable
+// through NewReceiver; it does not by itself make it reachable from an
+// XML config (see the package doc).
+func RegisterReceiverFactory(name string, factory ReceiverFactory) {
+	receiverFactoriesMu.Lock()
+	defer receiverFactoriesMu.Unlock()
+
+	receiverFactories[name] = factory
+}
+
+// NewReceiver builds the receiver registered under name (e.g. "conn",
+// "syslog", "file") from a set of attributes. <custom> is handled
+// separately, since its concrete receiver type is selected by its "name"
+// attribute rather than by name itself. This is the constructor an XML
+// parser would call once one exists to decode <outputs> child elements;
+// today it has no caller in this repository (see the package doc).
+func NewReceiver(name string, attrs map[string]string) (interface{}, error) {
+	if name == "custom" {
+		return newCustomReceiver(attrs)
+	}
+
+	receiverFactoriesMu.RLock()
+	factory, ok := receiverFactories[name]
+	receiverFactoriesMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown receiver type: %s", name)
+	}
+
+	return factory(attrs)
+}
+
+// CustomReceiverFactory builds a receiver from the attributes a
+// <custom name="..." .../> XML element would carry, minus the name
+// attribute itself.
+type CustomReceiverFactory func(args map[string]string) (io.WriteCloser, error)
+
+var (
+	customReceiverFactoriesMu sync.RWMutex
+	customReceiverFactories   = make(map[string]CustomReceiverFactory)
+)
+
+// RegisterCustomReceiver makes factory available under name.
+// log.RegisterReceiver is the public entry point downstream projects
+// call; it forwards here. Once there is a <sealog> XML parser, a <custom
+// name="name" .../> element will resolve to this via NewReceiver; until
+// then, name is only reachable by calling config.NewReceiver("custom",
+// map[string]string{"name": name, ...}) directly from Go.
+func RegisterCustomReceiver(name string, factory CustomReceiverFactory) {
+	customReceiverFactoriesMu.Lock()
+	defer customReceiverFactoriesMu.Unlock()
+
+	customReceiverFactories[name] = factory
+}
+
+func newCustomReceiver(attrs map[string]string) (interface{}, error) {
+	name, ok := attrs["name"]
+	if !ok {
+		return nil, fmt.Errorf("custom receiver is missing its name attribute")
+	}
+
+	customReceiverFactoriesMu.RLock()
+	factory, ok := customReceiverFactories[name]
+	customReceiverFactoriesMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("no custom receiver registered under name %q", name)
+	}
+
+	args := make(map[string]string, len(attrs))
+	for k, v := range attrs {
+		if k != "name" {
+			args[k] = v
+		}
+	}
+
+	return factory(args)
+}
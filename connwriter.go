@@ -0,0 +1,123 @@
+// Copyright 2011 Cloud Instruments Co. Ltd. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sealog
+
+import (
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/cihub/sealog/config"
+)
+
+func init() {
+	config.RegisterReceiverFactory("conn", newConnWriterFromConfig)
+}
+
+// newConnWriterFromConfig builds a connWriter from the attributes a
+// <conn net="tcp" addr="host:port" reconnectonmsg="true"
+// reconnect="false"/> element would carry. There is no <sealog> XML
+// parser in this repository yet, so this is only reachable today via
+// config.NewReceiver("conn", attrs) called directly from Go, not from an
+// actual config file.
+func newConnWriterFromConfig(attrs map[string]string) (interface{}, error) {
+	return NewConnWriter(attrs["net"], attrs["addr"], attrs["reconnect"] == "true", attrs["reconnectonmsg"] == "true")
+}
+
+// connWriter is a writer that writes to the given network connection. The
+// underlying socket is dialed lazily, on the first Write, and is either kept
+// open across messages or redialed before every message, depending on
+// reconnectOnMsg.
+type connWriter struct {
+	innerWriter    net.Conn
+	mu             sync.Mutex
+	netProto       string
+	addr           string
+	reconnect      bool
+	reconnectOnMsg bool
+}
+
+// NewConnWriter creates a writer that sends data over a TCP or UDP
+// connection. If reconnectOnMsg is true the connection is redialed before
+// every single message. Otherwise, if reconnect is true, a new connection
+// is dialed whenever the previous one is lost; if reconnect is false the
+// writer gives up once the connection fails.
+func NewConnWriter(netProto string, addr string, reconnect bool, reconnectOnMsg bool) (*connWriter, error) {
+	if netProto != "tcp" && netProto != "udp" {
+		return nil, fmt.Errorf("unknown network protocol: %s", netProto)
+	}
+
+	return &connWriter{
+		netProto:       netProto,
+		addr:           addr,
+		reconnect:      reconnect,
+		reconnectOnMsg: reconnectOnMsg,
+	}, nil
+}
+
+func (connWriter *connWriter) connect() error {
+	if connWriter.innerWriter != nil {
+		connWriter.innerWriter.Close()
+		connWriter.innerWriter = nil
+	}
+
+	conn, err := net.Dial(connWriter.netProto, connWriter.addr)
+	if err != nil {
+		return err
+	}
+
+	connWriter.innerWriter = conn
+
+	return nil
+}
+
+func (connWriter *connWriter) Write(bytes []byte) (n int, err error) {
+	connWriter.mu.Lock()
+	defer connWriter.mu.Unlock()
+
+	if connWriter.reconnectOnMsg {
+		if err := connWriter.connect(); err != nil {
+			return 0, err
+		}
+	}
+
+	if connWriter.innerWriter == nil {
+		if err := connWriter.connect(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err = connWriter.innerWriter.Write(bytes)
+	if err != nil && connWriter.reconnect {
+		if connectErr := connWriter.connect(); connectErr == nil {
+			return connWriter.innerWriter.Write(bytes)
+		}
+	}
+
+	return n, err
+}
+
+func (connWriter *connWriter) Close() error {
+	connWriter.mu.Lock()
+	defer connWriter.mu.Unlock()
+
+	if connWriter.innerWriter == nil {
+		return nil
+	}
+
+	err := connWriter.innerWriter.Close()
+	connWriter.innerWriter = nil
+
+	return err
+}
+
+func (connWriter *connWriter) Flush() {
+	// Nothing to flush, connWriter writes straight through to the socket.
+}
+
+func (connWriter *connWriter) String() string {
+	return fmt.Sprintf("Conn writer: [%s, %s, reconnect: %v, reconnectonmsg: %v]",
+		connWriter.netProto, connWriter.addr, connWriter.reconnect, connWriter.reconnectOnMsg)
+}
@@ -0,0 +1,34 @@
+// Copyright 2011 Cloud Instruments Co. Ltd. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sealog
+
+import (
+	"io"
+
+	"github.com/cihub/sealog/config"
+)
+
+// CustomReceiverFactory builds a receiver from the attributes a
+// <custom name="..." .../> XML element would carry. args holds every
+// attribute on the element except name itself.
+type CustomReceiverFactory func(args map[string]string) (io.WriteCloser, error)
+
+// RegisterReceiver makes a receiver factory available under name, so that
+// downstream projects can plug in sinks (Kafka, Elasticsearch, Sentry, ...)
+// without forking seelog. It is typically called from an init() func, e.g.:
+//
+//	func init() {
+//		log.RegisterReceiver("kafka", newKafkaWriter)
+//	}
+//
+// This repository has no <sealog> XML document parser yet, so
+// <custom name="kafka" .../> is not actually resolvable from a config
+// file today: name is only reachable by calling
+// config.NewReceiver("custom", map[string]string{"name": name, ...})
+// directly from Go. Once a parser exists, it can resolve <custom>
+// elements the same way.
+func RegisterReceiver(name string, factory CustomReceiverFactory) {
+	config.RegisterCustomReceiver(name, config.CustomReceiverFactory(factory))
+}
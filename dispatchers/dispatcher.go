@@ -21,17 +21,35 @@ import (
 type DispatcherInterface interface {
 	FlusherInterface
 	CloserInterface
+	ReopenerInterface
 	Dispatch(message string, level LogLevel, context *LogContext, errorFunc func(err error))
 }
 
+// A ReopenerInterface is implemented by receivers that hold onto an open
+// file descriptor (or similar resource) which can be renamed out from under
+// them, e.g. by logrotate. Reopen closes and reopens that resource in
+// place, without losing any buffered messages.
+type ReopenerInterface interface {
+	Reopen() error
+}
+
+// A LevelWriterInterface is implemented by receivers whose output depends on
+// the level of the message being written (e.g. a syslog writer, which maps
+// levels to syslog severities). Such receivers are written to directly,
+// bypassing the FormattedWriter used for plain io.Writer receivers.
+type LevelWriterInterface interface {
+	WriteLevel(message string, level LogLevel, context *LogContext) error
+}
+
 type dispatcher struct {
-	formatter   *format.Formatter
-	writers     []*FormattedWriter
-	dispatchers []DispatcherInterface
+	formatter    *format.Formatter
+	writers      []*FormattedWriter
+	levelWriters []LevelWriterInterface
+	dispatchers  []DispatcherInterface
 }
 
-// Creates a dispatcher which dispatches data to a list of receivers. 
-// Each receiver should be either a Dispatcher or io.Writer, otherwise an error will be returned
+// Creates a dispatcher which dispatches data to a list of receivers.
+// Each receiver should be a Dispatcher, io.Writer or LevelWriterInterface, otherwise an error will be returned
 func createDispatcher(formatter *format.Formatter, receivers []interface{}) (*dispatcher, error) {
 	if formatter == nil {
 		return nil, errors.New("Formatter can not be nil")
@@ -40,7 +58,7 @@ func createDispatcher(formatter *format.Formatter, receivers []interface{}) (*di
 		return nil, errors.New("Receivers can not be nil or empty")
 	}
 
-	disp := &dispatcher{formatter, make([]*FormattedWriter, 0), make([]DispatcherInterface, 0)}
+	disp := &dispatcher{formatter, make([]*FormattedWriter, 0), make([]LevelWriterInterface, 0), make([]DispatcherInterface, 0)}
 	for _, receiver := range receivers {
 		writer, ok := receiver.(*FormattedWriter)
 		if ok {
@@ -48,6 +66,12 @@ func createDispatcher(formatter *format.Formatter, receivers []interface{}) (*di
 			continue
 		}
 
+		levelWriter, ok := receiver.(LevelWriterInterface)
+		if ok {
+			disp.levelWriters = append(disp.levelWriters, levelWriter)
+			continue
+		}
+
 		ioWriter, ok := receiver.(io.Writer)
 		if ok {
 			writer, err := NewFormattedWriter(ioWriter, disp.formatter)
@@ -79,6 +103,13 @@ func (disp *dispatcher) Dispatch(message string, level LogLevel, context *LogCon
 		}
 	}
 
+	for _, levelWriter := range disp.levelWriters {
+		err := levelWriter.WriteLevel(message, level, context)
+		if err != nil {
+			errorFunc(err)
+		}
+	}
+
 	for _, dispInterface := range disp.dispatchers {
 		dispInterface.Dispatch(message, level, context, errorFunc)
 	}
@@ -92,7 +123,13 @@ func (disp *dispatcher) Flush() {
 	}
 	for _, formatWriter := range disp.Writers() {
 		flusher, ok := formatWriter.Writer().(FlusherInterface)
-		
+
+		if ok {
+			flusher.Flush()
+		}
+	}
+	for _, levelWriter := range disp.levelWriters {
+		flusher, ok := levelWriter.(FlusherInterface)
 		if ok {
 			flusher.Flush()
 		}
@@ -125,7 +162,59 @@ func (disp *dispatcher) Close() error {
 			}
 		}
 	}
-	
+
+	for _, levelWriter := range disp.levelWriters {
+		flusher, ok := levelWriter.(FlusherInterface)
+		if ok {
+			flusher.Flush()
+		}
+
+		closer, ok := levelWriter.(io.Closer)
+		if ok {
+			err := closer.Close()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Reopen flushes all underlying writers, then goes through them and, for
+// any writer which implements ReopenerInterface, closes and reopens its
+// underlying resource (e.g. a file that logrotate has renamed). Recursively
+// performs the same action for underlying dispatchers.
+func (disp *dispatcher) Reopen() error {
+	disp.Flush()
+
+	for _, disp := range disp.Dispatchers() {
+		err := disp.Reopen()
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, formatWriter := range disp.Writers() {
+		reopener, ok := formatWriter.Writer().(ReopenerInterface)
+		if ok {
+			err := reopener.Reopen()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, levelWriter := range disp.levelWriters {
+		reopener, ok := levelWriter.(ReopenerInterface)
+		if ok {
+			err := reopener.Reopen()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -164,5 +253,17 @@ func (disp *dispatcher) String() string {
 		}
 	}
 
+	str += "    ->LevelWriters:"
+
+	if len(disp.levelWriters) == 0 {
+		str += "none\n"
+	} else {
+		str += "\n"
+
+		for _, levelWriter := range disp.levelWriters {
+			str += fmt.Sprintf("        ->%s\n", levelWriter)
+		}
+	}
+
 	return str
 }
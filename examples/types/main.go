@@ -7,6 +7,7 @@ package main
 import (
 	"fmt"
 	log "github.com/cihub/sealog"
+	"github.com/cihub/sealog/common"
 	"strings"
 	"time"
 )
@@ -19,6 +20,8 @@ func main() {
 	asyncLoopBehavior()
 	fmt.Println()
 	asyncTimerBehavior()
+	fmt.Println()
+	minLevelForCallerBehavior()
 }
 
 func syncBehavior() {
@@ -89,6 +92,42 @@ func asyncTimerBehavior() {
 	time.Sleep(1e9)
 }
 
+// minLevelForCallerBehavior times the longMessage trace case twice against
+// otherwise-identical configs to demonstrate the speedup from skipping
+// runtime.Caller below MinLevelForCaller: once with the default (every
+// level captures the caller) and once with MinLevelForCaller raised to
+// warn, so Trace/Debug don't.
+//
+// MinLevelForCaller is set on the parsed LogConfig directly in Go rather
+// than as a <sealog minlevelforcaller="..."> attribute, since there is no
+// XML root-element attribute parser in this repository yet to read it out
+// of a config file.
+func minLevelForCallerBehavior() {
+	testConfig := `
+<sealog type="sync">
+	<outputs>
+		<filter levels="trace">
+			<file path="log.log"/>
+		</filter>
+		<filter levels="debug">
+			<console />
+		</filter>
+	</outputs>
+</sealog>
+`
+
+	fmt.Println("Minlevelforcaller test: without MinLevelForCaller")
+	baseline, _ := log.ConfigFromBytes([]byte(testConfig))
+	log.UseConfig(baseline)
+	doTest()
+
+	fmt.Println("Minlevelforcaller test: with MinLevelForCaller = warn")
+	fast, _ := log.ConfigFromBytes([]byte(testConfig))
+	fast.MinLevelForCaller = common.WarnLvl
+	log.UseConfig(fast)
+	doTest()
+}
+
 func doTest() {
 	start := time.Now()
 	for i := 0; i < 30; i += 2 {
@@ -0,0 +1,67 @@
+// Copyright 2011 Cloud Instruments Co. Ltd. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sealog
+
+import (
+	. "github.com/cihub/sealog/common"
+)
+
+// Fields is a set of structured key/value pairs that can be attached to a
+// log entry with WithFields, e.g.:
+//
+//	log.WithFields(Fields{"user": id, "req": rid}).Info("request handled")
+//
+// Fields are carried through to the LogContext and from there to writers,
+// so a JSONFormatter (or any other formatter that knows to look for them)
+// can render them alongside the usual message.
+//
+// NOTE: WithFields is only implemented on SyncLogger. This repository has
+// no async logger type (AsyncLoopLogger/AsyncTimerLogger) to attach an
+// equivalent to; adding structured logging for an async path is out of
+// scope until one exists.
+type Fields map[string]interface{}
+
+// fieldLogger decorates a SyncLogger with a fixed set of Fields that get
+// attached to every message logged through it.
+type fieldLogger struct {
+	commonLogger
+	fields Fields
+}
+
+// WithFields returns a logger that attaches fields to every subsequent
+// Trace/Debug/Info/Warn/Error/Critical call made through it. There is
+// currently no equivalent for an async logger; see the NOTE on Fields.
+func (syncLogger *SyncLogger) WithFields(fields Fields) *fieldLogger {
+	fl := &fieldLogger{fields: fields}
+	fl.commonLogger = *newCommonLogger(syncLogger.config, fl)
+
+	return fl
+}
+
+func (fl *fieldLogger) log(
+	level LogLevel,
+	format string,
+	params []interface{}) {
+
+	var context *LogContext
+
+	if level >= fl.config.MinLevelForCaller {
+		var err error
+		context, err = SpecificContext(3)
+		if err != nil {
+			reportInternalError(err)
+			return
+		}
+	} else {
+		// Trace/Debug messages below MinLevelForCaller skip the
+		// runtime.Caller lookup in SpecificContext entirely, same as
+		// SyncLogger.log, since WithFields shouldn't reintroduce the cost
+		// it was added to eliminate.
+		context = EmptyContext()
+	}
+	context.SetFields(fl.fields)
+
+	fl.processLogMsg(level, format, params, context)
+}
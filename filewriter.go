@@ -0,0 +1,33 @@
+// Copyright 2011 Cloud Instruments Co. Ltd. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sealog
+
+import (
+	"os"
+
+	"github.com/cihub/sealog/config"
+)
+
+// NewFileWriter opens path for appending (creating it if necessary) and
+// wraps it in a ReopenableWriter. This is the constructor that both the
+// plain <file> and <rollingfile> receivers must go through so that
+// Reopen/InstallReopenSignalHandler can actually pick up logrotate's
+// renames; a bare *os.File would keep writing to the renamed, unlinked
+// inode forever.
+func NewFileWriter(path string) (*ReopenableWriter, error) {
+	return NewReopenableWriter(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+}
+
+func init() {
+	config.RegisterReceiverFactory("file", newFileWriterFromConfig)
+}
+
+// newFileWriterFromConfig builds the receiver for a <file path="..."/>
+// element, routing it through NewFileWriter so it implements
+// dispatchers.ReopenerInterface. <rollingfile> is a distinct receiver type
+// with its own rotation policy and is not built by this factory.
+func newFileWriterFromConfig(attrs map[string]string) (interface{}, error) {
+	return NewFileWriter(attrs["path"])
+}
@@ -0,0 +1,76 @@
+// Copyright 2011 Cloud Instruments Co. Ltd. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package format
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	. "github.com/cihub/sealog/common"
+)
+
+func TestFormatJSONIncludesCoreFieldsAndFields(t *testing.T) {
+	callTime := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	context := NewLogContext("main.go", "main.doStuff", 42, callTime)
+	context.SetFields(map[string]interface{}{"user": "alice"})
+
+	line := formatJSON(context, InfoLvl, "hello", nil)
+
+	var record map[string]interface{}
+	if err := json.Unmarshal([]byte(line), &record); err != nil {
+		t.Fatalf("formatJSON produced invalid JSON: %v (%q)", err, line)
+	}
+
+	if record["msg"] != "hello" {
+		t.Errorf("msg = %v, want %q", record["msg"], "hello")
+	}
+	if record["file"] != "main.go" {
+		t.Errorf("file = %v, want %q", record["file"], "main.go")
+	}
+	if record["func"] != "main.doStuff" {
+		t.Errorf("func = %v, want %q", record["func"], "main.doStuff")
+	}
+
+	fields, ok := record["fields"].(map[string]interface{})
+	if !ok || fields["user"] != "alice" {
+		t.Errorf("fields = %v, want {user: alice}", record["fields"])
+	}
+}
+
+func TestFormatJSONOmitsFieldsWhenUnset(t *testing.T) {
+	context := NewLogContext("main.go", "main.doStuff", 1, time.Now())
+
+	line := formatJSON(context, InfoLvl, "hello", nil)
+
+	var record map[string]interface{}
+	if err := json.Unmarshal([]byte(line), &record); err != nil {
+		t.Fatalf("formatJSON produced invalid JSON: %v", err)
+	}
+	if _, ok := record["fields"]; ok {
+		t.Errorf(`record has a "fields" key with nothing attached via WithFields: %v`, record)
+	}
+}
+
+func TestFormatJSONAppliesCallerPrettyfier(t *testing.T) {
+	context := NewLogContext("github.com/foo/bar/baz.go", "github.com/foo/bar.(*T).Method", 1, time.Now())
+
+	prettyfier := func(context *LogContext) (string, string) {
+		return "baz.go", "T.Method"
+	}
+
+	line := formatJSON(context, InfoLvl, "hi", prettyfier)
+
+	var record map[string]interface{}
+	if err := json.Unmarshal([]byte(line), &record); err != nil {
+		t.Fatalf("formatJSON produced invalid JSON: %v", err)
+	}
+	if record["func"] != "T.Method" {
+		t.Errorf("func = %v, want %q (prettyfier should override the raw value)", record["func"], "T.Method")
+	}
+	if record["file"] != "baz.go" {
+		t.Errorf("file = %v, want %q (prettyfier should override the raw value)", record["file"], "baz.go")
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright 2011 Cloud Instruments Co. Ltd. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sealog
+
+import (
+	"os"
+	"os/signal"
+)
+
+// Reopen flushes the currently active logger and asks every writer in its
+// root dispatcher that implements dispatchers.ReopenerInterface to close
+// and reopen its underlying resource. Use this after an external tool like
+// logrotate has renamed a log file out from under seelog.
+func Reopen() error {
+	return Current.Reopen()
+}
+
+// InstallReopenSignalHandler starts a goroutine that calls Reopen whenever
+// the process receives any of the given signals. It is opt-in: callers
+// that want `logrotate`'s postrotate hook to trigger a reopen via SIGHUP
+// should call InstallReopenSignalHandler(syscall.SIGHUP) once at startup.
+func InstallReopenSignalHandler(signals ...os.Signal) {
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, signals...)
+
+	go func() {
+		for range sigChan {
+			if err := Reopen(); err != nil {
+				reportInternalError(err)
+			}
+		}
+	}()
+}
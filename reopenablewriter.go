@@ -0,0 +1,80 @@
+// Copyright 2011 Cloud Instruments Co. Ltd. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sealog
+
+import (
+	"os"
+	"sync"
+)
+
+// ReopenableWriter wraps a file receiver (plain <file> or <rollingfile>) so
+// that it can be safely closed and reopened at the same path while the
+// process keeps running, e.g. after `logrotate` has renamed the file out
+// from under it. A mutex guards the underlying *os.File so a reopen never
+// races with a concurrent Dispatch.
+type ReopenableWriter struct {
+	mu   sync.Mutex
+	path string
+	flag int
+	perm os.FileMode
+	file *os.File
+}
+
+// NewReopenableWriter opens path with the given flag/perm and wraps it in a
+// ReopenableWriter.
+func NewReopenableWriter(path string, flag int, perm os.FileMode) (*ReopenableWriter, error) {
+	file, err := os.OpenFile(path, flag, perm)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReopenableWriter{path: path, flag: flag, perm: perm, file: file}, nil
+}
+
+func (writer *ReopenableWriter) Write(bytes []byte) (int, error) {
+	writer.mu.Lock()
+	defer writer.mu.Unlock()
+
+	return writer.file.Write(bytes)
+}
+
+// Reopen opens path again, picking up a fresh inode if the file was renamed
+// or removed since it was last opened, then swaps it in and closes the old
+// file. The old file is only closed once the new one has been opened
+// successfully, so a failed reopen (e.g. racing with logrotate before it
+// has created the new path) leaves the writer fully functional on the file
+// it already had open, instead of stuck with a closed fd.
+func (writer *ReopenableWriter) Reopen() error {
+	writer.mu.Lock()
+	defer writer.mu.Unlock()
+
+	file, err := os.OpenFile(writer.path, writer.flag, writer.perm)
+	if err != nil {
+		return err
+	}
+
+	oldFile := writer.file
+	writer.file = file
+
+	return oldFile.Close()
+}
+
+func (writer *ReopenableWriter) Flush() {
+	writer.mu.Lock()
+	defer writer.mu.Unlock()
+
+	writer.file.Sync()
+}
+
+func (writer *ReopenableWriter) Close() error {
+	writer.mu.Lock()
+	defer writer.mu.Unlock()
+
+	return writer.file.Close()
+}
+
+func (writer *ReopenableWriter) String() string {
+	return "Reopenable writer: " + writer.path
+}
@@ -0,0 +1,77 @@
+// Copyright 2011 Cloud Instruments Co. Ltd. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sealog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newTestReopenableWriter(t *testing.T, path string) *ReopenableWriter {
+	t.Helper()
+
+	writer, err := NewReopenableWriter(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		t.Fatalf("NewReopenableWriter: %v", err)
+	}
+	return writer
+}
+
+func TestReopenableWriterReopenPicksUpRenamedPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.log")
+	writer := newTestReopenableWriter(t, path)
+	defer writer.Close()
+
+	if _, err := writer.Write([]byte("before\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	if err := os.Rename(path, path+".1"); err != nil {
+		t.Fatalf("Rename: %v", err)
+	}
+
+	if err := writer.Reopen(); err != nil {
+		t.Fatalf("Reopen: %v", err)
+	}
+
+	if _, err := writer.Write([]byte("after\n")); err != nil {
+		t.Fatalf("Write after reopen: %v", err)
+	}
+
+	rotated, err := os.ReadFile(path + ".1")
+	if err != nil {
+		t.Fatalf("ReadFile rotated: %v", err)
+	}
+	if string(rotated) != "before\n" {
+		t.Errorf("rotated file = %q, want %q", rotated, "before\n")
+	}
+
+	current, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile current: %v", err)
+	}
+	if string(current) != "after\n" {
+		t.Errorf("current file = %q, want %q", current, "after\n")
+	}
+}
+
+func TestReopenableWriterFailedReopenKeepsOldFileUsable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.log")
+	writer := newTestReopenableWriter(t, path)
+	defer writer.Close()
+
+	// Point Reopen at a directory that doesn't exist, without touching
+	// the file the writer already has open.
+	writer.path = filepath.Join(filepath.Dir(path), "missing-dir", "out.log")
+
+	if err := writer.Reopen(); err == nil {
+		t.Fatal("Reopen: expected an error for a nonexistent directory")
+	}
+
+	if _, err := writer.Write([]byte("still here\n")); err != nil {
+		t.Errorf("Write after failed Reopen: %v", err)
+	}
+}
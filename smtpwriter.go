@@ -0,0 +1,238 @@
+// Copyright 2011 Cloud Instruments Co. Ltd. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sealog
+
+import (
+	"fmt"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cihub/sealog/config"
+)
+
+const (
+	defaultSMTPFlushInterval = 30 * time.Second
+	// defaultSMTPBatchSize matches the batchsize used in the <smtp>
+	// example this receiver was built for. It must stay well above 1: a
+	// lower fallback would flush on every single Write, turning exactly
+	// the high-severity storm this writer exists to batch into one email
+	// per line again.
+	defaultSMTPBatchSize = 20
+)
+
+func init() {
+	config.RegisterReceiverFactory("smtp", newSMTPWriterFromConfig)
+}
+
+// newSMTPWriterFromConfig builds a smtpWriter from the attributes a
+// <smtp host="..." port="587" username="..." password="..." auth="plain"
+// from="..." to="a@x,b@y" subject="..." batchsize="20"
+// flushinterval="30"/> element would carry. auth selects the
+// SMTPAuthMode ("plain", "login", or omitted for none); batchsize/
+// flushinterval fall back to defaultSMTPBatchSize/defaultSMTPFlushInterval
+// when absent or invalid. There is no <sealog> XML parser in this
+// repository yet, so this is only reachable today via
+// config.NewReceiver("smtp", attrs) called directly from Go, not from an
+// actual config file.
+func newSMTPWriterFromConfig(attrs map[string]string) (interface{}, error) {
+	authMode := SMTPAuthNone
+	switch attrs["auth"] {
+	case "plain":
+		authMode = SMTPAuthPlain
+	case "login":
+		authMode = SMTPAuthLogin
+	}
+
+	batchSize := defaultSMTPBatchSize
+	if parsed, err := strconv.Atoi(attrs["batchsize"]); err == nil && parsed > 0 {
+		batchSize = parsed
+	}
+
+	flushInterval := defaultSMTPFlushInterval
+	if seconds, err := strconv.Atoi(attrs["flushinterval"]); err == nil && seconds > 0 {
+		flushInterval = time.Duration(seconds) * time.Second
+	}
+
+	return NewSMTPWriter(attrs["host"], attrs["port"], attrs["username"], attrs["password"], authMode,
+		attrs["from"], attrs["to"], attrs["subject"], batchSize, flushInterval)
+}
+
+// SMTPAuthMode selects how smtpWriter authenticates with the SMTP server.
+type SMTPAuthMode int
+
+const (
+	// SMTPAuthNone sends no AUTH command at all.
+	SMTPAuthNone SMTPAuthMode = iota
+	// SMTPAuthPlain uses AUTH PLAIN (smtp.PlainAuth).
+	SMTPAuthPlain
+	// SMTPAuthLogin uses AUTH LOGIN, required by servers (e.g. older
+	// Exchange/Office365 setups) that don't support PLAIN.
+	SMTPAuthLogin
+)
+
+// smtpLoginAuth implements smtp.Auth for the AUTH LOGIN mechanism, which
+// the standard library does not provide: the server prompts for "Username"
+// and "Password" in turn instead of taking both in a single response.
+type smtpLoginAuth struct {
+	username string
+	password string
+}
+
+func (a *smtpLoginAuth) Start(server *smtp.ServerInfo) (string, []byte, error) {
+	return "LOGIN", nil, nil
+}
+
+func (a *smtpLoginAuth) Next(fromServer []byte, more bool) ([]byte, error) {
+	if !more {
+		return nil, nil
+	}
+
+	switch strings.ToLower(strings.TrimSuffix(string(fromServer), ":")) {
+	case "username":
+		return []byte(a.username), nil
+	case "password":
+		return []byte(a.password), nil
+	default:
+		return nil, fmt.Errorf("smtp: unexpected LOGIN challenge: %s", fromServer)
+	}
+}
+
+// smtpWriter buffers formatted records and delivers them as a single,
+// multi-line email once batchSize messages have accumulated or
+// flushInterval has elapsed, whichever comes first. This keeps a burst of
+// high-severity log lines from turning into one email per line.
+type smtpWriter struct {
+	mu       sync.Mutex
+	host     string
+	port     string
+	username string
+	password string
+	authMode SMTPAuthMode
+	from     string
+	to       []string
+	subject  string
+
+	batchSize int
+	buffered  []string
+
+	ticker *time.Ticker
+	done   chan struct{}
+}
+
+// NewSMTPWriter creates a writer that emails buffered log records through
+// the given SMTP server, authenticating per authMode (username is not
+// checked: SMTPAuthPlain and SMTPAuthLogin send credentials even if empty).
+// to is a comma-separated list of recipients. The batch is flushed after
+// batchSize messages or flushInterval, whichever happens first. STARTTLS is
+// negotiated automatically whenever the server advertises it, regardless
+// of authMode.
+func NewSMTPWriter(host, port, username, password string, authMode SMTPAuthMode, from, to, subject string, batchSize int, flushInterval time.Duration) (*smtpWriter, error) {
+	if host == "" || port == "" {
+		return nil, fmt.Errorf("smtp: host and port are required")
+	}
+	if from == "" || to == "" {
+		return nil, fmt.Errorf("smtp: from and to are required")
+	}
+	if batchSize <= 0 {
+		batchSize = defaultSMTPBatchSize
+	}
+	if flushInterval <= 0 {
+		flushInterval = defaultSMTPFlushInterval
+	}
+
+	writer := &smtpWriter{
+		host:      host,
+		port:      port,
+		username:  username,
+		password:  password,
+		authMode:  authMode,
+		from:      from,
+		to:        strings.Split(to, ","),
+		subject:   subject,
+		batchSize: batchSize,
+		buffered:  make([]string, 0, batchSize),
+		ticker:    time.NewTicker(flushInterval),
+		done:      make(chan struct{}),
+	}
+
+	go writer.loop()
+
+	return writer, nil
+}
+
+func (smtpWriter *smtpWriter) loop() {
+	for {
+		select {
+		case <-smtpWriter.ticker.C:
+			smtpWriter.Flush()
+		case <-smtpWriter.done:
+			return
+		}
+	}
+}
+
+func (smtpWriter *smtpWriter) Write(bytes []byte) (int, error) {
+	smtpWriter.mu.Lock()
+	smtpWriter.buffered = append(smtpWriter.buffered, string(bytes))
+	shouldFlush := len(smtpWriter.buffered) >= smtpWriter.batchSize
+	smtpWriter.mu.Unlock()
+
+	if shouldFlush {
+		smtpWriter.Flush()
+	}
+
+	return len(bytes), nil
+}
+
+// Flush sends whatever is currently buffered as a single email and clears
+// the buffer. It is a no-op when nothing is buffered.
+func (smtpWriter *smtpWriter) Flush() {
+	smtpWriter.mu.Lock()
+	if len(smtpWriter.buffered) == 0 {
+		smtpWriter.mu.Unlock()
+		return
+	}
+	batch := smtpWriter.buffered
+	smtpWriter.buffered = make([]string, 0, smtpWriter.batchSize)
+	smtpWriter.mu.Unlock()
+
+	if err := smtpWriter.send(batch); err != nil {
+		reportInternalError(err)
+	}
+}
+
+func (smtpWriter *smtpWriter) send(batch []string) error {
+	addr := smtpWriter.host + ":" + smtpWriter.port
+
+	var auth smtp.Auth
+	switch smtpWriter.authMode {
+	case SMTPAuthPlain:
+		auth = smtp.PlainAuth("", smtpWriter.username, smtpWriter.password, smtpWriter.host)
+	case SMTPAuthLogin:
+		auth = &smtpLoginAuth{username: smtpWriter.username, password: smtpWriter.password}
+	case SMTPAuthNone:
+		auth = nil
+	}
+
+	body := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s",
+		smtpWriter.from, strings.Join(smtpWriter.to, ","), smtpWriter.subject, strings.Join(batch, ""))
+
+	return smtp.SendMail(addr, auth, smtpWriter.from, smtpWriter.to, []byte(body))
+}
+
+func (smtpWriter *smtpWriter) Close() error {
+	close(smtpWriter.done)
+	smtpWriter.ticker.Stop()
+	smtpWriter.Flush()
+
+	return nil
+}
+
+func (smtpWriter *smtpWriter) String() string {
+	return fmt.Sprintf("SMTP writer: [%s:%s, from: %s, to: %s]", smtpWriter.host, smtpWriter.port, smtpWriter.from, strings.Join(smtpWriter.to, ","))
+}
@@ -0,0 +1,62 @@
+// Copyright 2011 Cloud Instruments Co. Ltd. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sealog
+
+import "testing"
+
+func TestNewSMTPWriterDefaultsBatchSize(t *testing.T) {
+	writer, err := NewSMTPWriter("smtp.example.com", "587", "", "", SMTPAuthNone,
+		"from@example.com", "to@example.com", "subj", 0, 0)
+	if err != nil {
+		t.Fatalf("NewSMTPWriter: %v", err)
+	}
+	defer writer.Close()
+
+	if writer.batchSize != defaultSMTPBatchSize {
+		t.Errorf("batchSize = %d, want %d (a fallback of 1 would flush on every Write)", writer.batchSize, defaultSMTPBatchSize)
+	}
+}
+
+func TestNewSMTPWriterFromConfigDefaultsBatchSize(t *testing.T) {
+	receiver, err := newSMTPWriterFromConfig(map[string]string{
+		"host": "smtp.example.com",
+		"port": "587",
+		"from": "from@example.com",
+		"to":   "to@example.com",
+	})
+	if err != nil {
+		t.Fatalf("newSMTPWriterFromConfig: %v", err)
+	}
+
+	writer, ok := receiver.(*smtpWriter)
+	if !ok {
+		t.Fatalf("receiver is %T, want *smtpWriter", receiver)
+	}
+	defer writer.Close()
+
+	if writer.batchSize != defaultSMTPBatchSize {
+		t.Errorf("batchSize = %d, want %d when batchsize is omitted from <smtp>", writer.batchSize, defaultSMTPBatchSize)
+	}
+}
+
+func TestNewSMTPWriterFromConfigHonorsExplicitBatchSize(t *testing.T) {
+	receiver, err := newSMTPWriterFromConfig(map[string]string{
+		"host":      "smtp.example.com",
+		"port":      "587",
+		"from":      "from@example.com",
+		"to":        "to@example.com",
+		"batchsize": "5",
+	})
+	if err != nil {
+		t.Fatalf("newSMTPWriterFromConfig: %v", err)
+	}
+
+	writer := receiver.(*smtpWriter)
+	defer writer.Close()
+
+	if writer.batchSize != 5 {
+		t.Errorf("batchSize = %d, want 5", writer.batchSize)
+	}
+}
@@ -25,16 +25,26 @@ func NewSyncLogger(config *cfg.LogConfig) (*SyncLogger){
 }
 
 func (cLogger *SyncLogger) log(
-    level LogLevel, 
-	format string, 
+    level LogLevel,
+	format string,
 	params []interface{}) {
-	
-	context, err := SpecificContext(3)
-	if err != nil {
-		reportInternalError(err)
-		return
+
+	var context *LogContext
+
+	if level >= cLogger.config.MinLevelForCaller {
+		var err error
+		context, err = SpecificContext(3)
+		if err != nil {
+			reportInternalError(err)
+			return
+		}
+	} else {
+		// Trace/Debug messages below MinLevelForCaller skip the
+		// runtime.Caller lookup in SpecificContext entirely, since it
+		// dominates the cost of logging a message on the hot path.
+		context = EmptyContext()
 	}
-		
+
 	cLogger.processLogMsg(level, format, params, context)
 }
 
@@ -44,4 +54,8 @@ func (syncLogger *SyncLogger) Close() {
 
 func (syncLogger *SyncLogger) Flush() {
 	syncLogger.config.RootDispatcher.Flush()
+}
+
+func (syncLogger *SyncLogger) Reopen() error {
+	return syncLogger.config.RootDispatcher.Reopen()
 }
\ No newline at end of file
@@ -0,0 +1,255 @@
+// Copyright 2011 Cloud Instruments Co. Ltd. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sealog
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	. "github.com/cihub/sealog/common"
+	"github.com/cihub/sealog/config"
+)
+
+func init() {
+	config.RegisterReceiverFactory("syslog", newSyslogWriterFromConfig)
+}
+
+// newSyslogWriterFromConfig builds a syslogWriter from the attributes a
+// <syslog network="udp" addr="host:514" facility="local0" tag="myapp"
+// format="rfc3164"/> element would carry. network/addr are omitted to log
+// to the local syslog daemon. format selects the wire framing and
+// defaults to RFC5424Format when omitted or unrecognized. There is no
+// <sealog> XML parser in this repository yet, so this is only reachable
+// today via config.NewReceiver("syslog", attrs) called directly from Go,
+// not from an actual config file.
+func newSyslogWriterFromConfig(attrs map[string]string) (interface{}, error) {
+	format := RFC5424Format
+	if attrs["format"] == "rfc3164" {
+		format = RFC3164Format
+	}
+
+	return NewSyslogWriter(attrs["network"], attrs["addr"], attrs["facility"], attrs["tag"], format)
+}
+
+// syslog severities, as defined by RFC 5424
+const (
+	syslogSeverityDebug    = 7
+	syslogSeverityInfo     = 6
+	syslogSeverityWarning  = 4
+	syslogSeverityError    = 3
+	syslogSeverityCritical = 2
+)
+
+// SyslogFormat selects the wire framing used when writing to the syslog
+// daemon.
+type SyslogFormat int
+
+const (
+	// RFC5424Format emits the structured, framed format from RFC 5424.
+	RFC5424Format SyslogFormat = iota
+	// RFC3164Format emits the legacy BSD syslog format from RFC 3164,
+	// understood by most local syslogd implementations.
+	RFC3164Format
+)
+
+// rfc3164TimeFormat is the fixed-width "Mmm dd hh:mm:ss" timestamp required
+// by RFC 3164 (a day-of-month below 10 is space-padded, not zero-padded).
+const rfc3164TimeFormat = "Jan _2 15:04:05"
+
+// syslogFacilities maps facility names to the numeric code understood by syslogd,
+// e.g. 16 for "local0".
+var syslogFacilities = map[string]int{
+	"kern": 0, "user": 1, "mail": 2, "daemon": 3, "auth": 4, "syslog": 5,
+	"lpr": 6, "news": 7, "uucp": 8, "cron": 9, "authpriv": 10, "ftp": 11,
+	"local0": 16, "local1": 17, "local2": 18, "local3": 19,
+	"local4": 20, "local5": 21, "local6": 22, "local7": 23,
+}
+
+// severityForLevel maps a sealog level to the closest syslog severity.
+func severityForLevel(level LogLevel) int {
+	switch level {
+	case TraceLvl, DebugLvl:
+		return syslogSeverityDebug
+	case InfoLvl:
+		return syslogSeverityInfo
+	case WarnLvl:
+		return syslogSeverityWarning
+	case ErrorLvl:
+		return syslogSeverityError
+	case CriticalLvl:
+		return syslogSeverityCritical
+	}
+	return syslogSeverityInfo
+}
+
+// syslogWriter writes framed RFC 5424 (or, per format, RFC 3164) messages to
+// a local or remote syslog daemon. When network is empty the writer dials the local Unix socket
+// (/dev/log or /var/run/syslog), otherwise it dials network/addr (e.g.
+// "udp", "host:514").
+type syslogWriter struct {
+	mu       sync.Mutex
+	conn     net.Conn
+	network  string
+	addr     string
+	facility int
+	tag      string
+	hostname string
+	format   SyslogFormat
+}
+
+// NewSyslogWriter creates a writer that forwards messages to a syslog
+// daemon. facility must be one of the standard syslog facility names
+// ("local0", "daemon", "auth", ...); tag is the RFC 5424 app-name (or the
+// RFC 3164 TAG). If network is empty, addr is ignored and the writer dials
+// the local syslog Unix socket instead. format selects the wire framing:
+// RFC5424Format (the default most remote collectors expect) or
+// RFC3164Format (the legacy BSD format most local syslogd daemons expect).
+func NewSyslogWriter(network string, addr string, facility string, tag string, format SyslogFormat) (*syslogWriter, error) {
+	facilityCode, ok := syslogFacilities[facility]
+	if !ok {
+		return nil, fmt.Errorf("unknown syslog facility: %s", facility)
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "localhost"
+	}
+
+	if tag == "" {
+		tag = os.Args[0]
+	}
+
+	return &syslogWriter{
+		network:  network,
+		addr:     addr,
+		facility: facilityCode,
+		tag:      tag,
+		hostname: hostname,
+		format:   format,
+	}, nil
+}
+
+func (syslogWriter *syslogWriter) connect() error {
+	if syslogWriter.conn != nil {
+		syslogWriter.conn.Close()
+		syslogWriter.conn = nil
+	}
+
+	if syslogWriter.network == "" {
+		conn, err := dialLocalSyslog()
+		if err != nil {
+			return err
+		}
+		syslogWriter.conn = conn
+		return nil
+	}
+
+	conn, err := net.Dial(syslogWriter.network, syslogWriter.addr)
+	if err != nil {
+		return err
+	}
+	syslogWriter.conn = conn
+
+	return nil
+}
+
+// dialLocalSyslog tries the well-known Unix syslog socket locations.
+func dialLocalSyslog() (net.Conn, error) {
+	var lastErr error
+	for _, socket := range []string{"/dev/log", "/var/run/syslog", "/var/run/log"} {
+		conn, err := net.Dial("unixgram", socket)
+		if err == nil {
+			return conn, nil
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+// WriteLevel sends a single log record, framed as an RFC 5424 or RFC 3164
+// message according to the writer's format, to the syslog daemon. It implements dispatchers.LevelWriterInterface, since
+// the syslog severity depends on the level of the message being written.
+func (syslogWriter *syslogWriter) WriteLevel(message string, level LogLevel, context *LogContext) error {
+	syslogWriter.mu.Lock()
+	defer syslogWriter.mu.Unlock()
+
+	if syslogWriter.conn == nil {
+		if err := syslogWriter.connect(); err != nil {
+			return err
+		}
+	}
+
+	priority := syslogWriter.facility*8 + severityForLevel(level)
+
+	var record string
+	if syslogWriter.format == RFC3164Format {
+		record = syslogWriter.formatRFC3164(priority, message)
+	} else {
+		record = syslogWriter.formatRFC5424(priority, message, context)
+	}
+
+	_, err := syslogWriter.conn.Write([]byte(record))
+	if err != nil {
+		syslogWriter.conn = nil
+	}
+
+	return err
+}
+
+// formatRFC5424 frames message as a structured RFC 5424 record, including
+// timestamp, hostname, app-name (tag) and structured data derived from
+// context.
+func (syslogWriter *syslogWriter) formatRFC5424(priority int, message string, context *LogContext) string {
+	structuredData := "-"
+	if context != nil {
+		structuredData = fmt.Sprintf("[meta func=\"%s\" line=\"%d\"]", context.Func(), context.Line())
+	}
+
+	return fmt.Sprintf("<%d>1 %s %s %s %d - %s %s\n",
+		priority,
+		time.Now().Format(time.RFC3339),
+		syslogWriter.hostname,
+		syslogWriter.tag,
+		os.Getpid(),
+		structuredData,
+		message)
+}
+
+// formatRFC3164 frames message as a legacy BSD syslog record: the format
+// still expected by most local syslogd implementations.
+func (syslogWriter *syslogWriter) formatRFC3164(priority int, message string) string {
+	return fmt.Sprintf("<%d>%s %s %s[%d]: %s\n",
+		priority,
+		time.Now().Format(rfc3164TimeFormat),
+		syslogWriter.hostname,
+		syslogWriter.tag,
+		os.Getpid(),
+		message)
+}
+
+func (syslogWriter *syslogWriter) Flush() {
+	// syslogWriter has nothing buffered; every message is sent immediately.
+}
+
+func (syslogWriter *syslogWriter) Close() error {
+	syslogWriter.mu.Lock()
+	defer syslogWriter.mu.Unlock()
+
+	if syslogWriter.conn == nil {
+		return nil
+	}
+
+	err := syslogWriter.conn.Close()
+	syslogWriter.conn = nil
+
+	return err
+}
+
+func (syslogWriter *syslogWriter) String() string {
+	return fmt.Sprintf("Syslog writer: [%s, %s, tag: %s]", syslogWriter.network, syslogWriter.addr, syslogWriter.tag)
+}
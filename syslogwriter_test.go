@@ -0,0 +1,69 @@
+// Copyright 2011 Cloud Instruments Co. Ltd. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sealog
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/cihub/sealog/common"
+)
+
+func TestSeverityForLevel(t *testing.T) {
+	cases := []struct {
+		level LogLevel
+		want  int
+	}{
+		{TraceLvl, syslogSeverityDebug},
+		{DebugLvl, syslogSeverityDebug},
+		{InfoLvl, syslogSeverityInfo},
+		{WarnLvl, syslogSeverityWarning},
+		{ErrorLvl, syslogSeverityError},
+		{CriticalLvl, syslogSeverityCritical},
+	}
+
+	for _, c := range cases {
+		if got := severityForLevel(c.level); got != c.want {
+			t.Errorf("severityForLevel(%v) = %d, want %d", c.level, got, c.want)
+		}
+	}
+}
+
+func TestFormatRFC3164UsesBSDLayout(t *testing.T) {
+	writer, err := NewSyslogWriter("", "", "local0", "myapp", RFC3164Format)
+	if err != nil {
+		t.Fatalf("NewSyslogWriter: %v", err)
+	}
+
+	record := writer.formatRFC3164(16*8+syslogSeverityInfo, "hello")
+
+	if !strings.Contains(record, "myapp[") {
+		t.Errorf("RFC3164 record missing tag[pid]: %q", record)
+	}
+	if !strings.HasPrefix(record, "<") {
+		t.Errorf("RFC3164 record missing priority prefix: %q", record)
+	}
+	if !strings.HasSuffix(record, "hello\n") {
+		t.Errorf("RFC3164 record = %q, want it to end with the message", record)
+	}
+}
+
+func TestFormatRFC5424IncludesStructuredData(t *testing.T) {
+	writer, err := NewSyslogWriter("", "", "local0", "myapp", RFC5424Format)
+	if err != nil {
+		t.Fatalf("NewSyslogWriter: %v", err)
+	}
+
+	context := NewLogContext("main.go", "main.run", 7, time.Now())
+	record := writer.formatRFC5424(16*8+syslogSeverityInfo, "hello", context)
+
+	if !strings.Contains(record, `func="main.run"`) {
+		t.Errorf("RFC5424 record missing structured data: %q", record)
+	}
+	if !strings.HasSuffix(record, "hello\n") {
+		t.Errorf("RFC5424 record = %q, want it to end with the message", record)
+	}
+}